@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"os"
 
@@ -19,8 +18,11 @@ Commands:
 	generate	Generate new OpenFIGI symbols and return them all at once
 	genstream	Generate new OpenFIGI symbols and return them as a stream (one-by-one)
 	validate	Check if a given string is a valid OpenFIGI symbol
-	valstream	Validate existing OpenFIGI symbols from a file or stdin and return the 
+	valstream	Validate existing OpenFIGI symbols from a file or stdin and return the
 			results as a stream (one-by-one)
+	map		Resolve third-party identifiers (ISIN, CUSIP, ticker, ...) to FIGIs using
+			the OpenFIGI mapping API
+	search		Search the OpenFIGI catalog by free text using the OpenFIGI search API
 `)
 	if err != nil {
 		return
@@ -31,11 +33,16 @@ Commands:
 func runGenerate(svc openfigi.FIGIService, args []string) error {
 	command := flag.NewFlagSet("generate", flag.ExitOnError)
 	n := command.Uint("n", 1, "Number of symbols to generate")
+	seed := command.Int64("seed", 0, "Seed for reproducible generation; if unset, a time-based seed is used")
 	err := command.Parse(args)
 	if err != nil {
 		return err
 	}
 
+	if seedSet(command, "seed") {
+		svc = openfigi.NewServiceWithSeed(*seed)
+	}
+
 	symbols := svc.Generate(*n)
 	for _, symbol := range symbols {
 		fmt.Println(symbol)
@@ -48,11 +55,16 @@ func runGenerate(svc openfigi.FIGIService, args []string) error {
 func runGenstream(ctx context.Context, svc openfigi.FIGIService, args []string) error {
 	command := flag.NewFlagSet("genstream", flag.ExitOnError)
 	n := command.Uint("n", 1, "Number of symbols to generate")
+	seed := command.Int64("seed", 0, "Seed for reproducible generation; if unset, a time-based seed is used")
 	err := command.Parse(args)
 	if err != nil {
 		return err
 	}
 
+	if seedSet(command, "seed") {
+		svc = openfigi.NewServiceWithSeed(*seed)
+	}
+
 	rcvChan := svc.GenerateStream(ctx, *n)
 
 	for {
@@ -68,73 +80,17 @@ func runGenstream(ctx context.Context, svc openfigi.FIGIService, args []string)
 	}
 }
 
-// runValidate executes a "validate" command to validate a given string.
-func runValidate(svc openfigi.FIGIService, args []string) error {
-	command := flag.NewFlagSet("validate", flag.ExitOnError)
-	s := command.String("s", "", "String to validate")
-	err := command.Parse(args)
-	if err != nil {
-		return err
-	}
-
-	isValid, message := svc.Validate(*s)
-
-	if isValid {
-		fmt.Println("Valid")
-	} else {
-		fmt.Printf("Invalid (Reason: %s)\n", message)
-	}
-
-	return nil
-}
-
-// runValstream executes a "valstream" command to validate OpenFIGI symbols from a file or stdin.
-func runValstream(ctx context.Context, svc openfigi.FIGIService, args []string) error {
-	command := flag.NewFlagSet("valstream", flag.ExitOnError)
-	err := command.Parse(args)
-	if err != nil {
-		return err
-	}
-
-	var reader io.Reader
-
-	if command.NArg() == 0 { // No file provided, use stdin instead
-		reader = os.Stdin
-	} else {
-		file, openingErr := os.Open(command.Arg(0))
-		if openingErr != nil {
-			return openingErr
-		}
-
-		defer func(file *os.File) {
-			err = file.Close()
-			if err != nil {
-				log.Fatalf("Error closing file: %v", err)
-			}
-		}(file)
-
-		reader = file
-	}
-
-	rcvChan := svc.ValidateStream(ctx, reader)
-
-	for {
-		select {
-		case <-ctx.Done(): // Unexpected interruption
-			return ctx.Err()
-		case result, ok := <-rcvChan:
-			if !ok {
-				return nil
-			}
-
-			fmt.Printf("%s is ", result.Input)
-			if result.IsValid {
-				fmt.Println("valid")
-			} else {
-				fmt.Printf("invalid (reason: %s)\n", result.Message)
-			}
+// seedSet reports whether the named flag was explicitly passed on the command line, as
+// opposed to left at its zero-value default. This lets "-seed 0" be distinguished from no
+// "-seed" flag at all, since 0 is itself a valid seed.
+func seedSet(command *flag.FlagSet, name string) bool {
+	set := false
+	command.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
 		}
-	}
+	})
+	return set
 }
 
 func main() {
@@ -164,6 +120,14 @@ func main() {
 		if err := runValstream(ctx, service, os.Args[2:]); err != nil {
 			log.Fatalf("Error in valstream command: %v", err)
 		}
+	case "map":
+		if err := runMap(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("Error in map command: %v", err)
+		}
+	case "search":
+		if err := runSearch(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("Error in search command: %v", err)
+		}
 	default:
 		printUsageGuide()
 		os.Exit(1)
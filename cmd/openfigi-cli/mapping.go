@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/Gandook/openfigi-lib/openfigi"
+)
+
+// runMap executes a "map" command to resolve third-party identifiers to FIGIs using the
+// OpenFIGI mapping API.
+func runMap(ctx context.Context, args []string) error {
+	command := flag.NewFlagSet("map", flag.ExitOnError)
+	file := command.String("file", "", "JSON or CSV file containing the mapping jobs")
+	format := command.String("format", "json", "Format of -file: \"json\" or \"csv\"")
+	apiKey := command.String("apikey", "", "OpenFIGI API key (optional, raises the rate limit)")
+	if err := command.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	jobs, err := readMappingJobs(*file, *format)
+	if err != nil {
+		return err
+	}
+
+	client := newMappingClient(*apiKey)
+
+	results, err := client.Map(ctx, jobs)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(results)
+}
+
+// runSearch executes a "search" command to perform a free-text FIGI lookup via the OpenFIGI
+// search API.
+func runSearch(ctx context.Context, args []string) error {
+	command := flag.NewFlagSet("search", flag.ExitOnError)
+	query := command.String("query", "", "Free-text search query")
+	exchCode := command.String("exchcode", "", "Restrict results to this exchange code")
+	currency := command.String("currency", "", "Restrict results to this currency")
+	apiKey := command.String("apikey", "", "OpenFIGI API key (optional, raises the rate limit)")
+	if err := command.Parse(args); err != nil {
+		return err
+	}
+
+	if *query == "" {
+		return fmt.Errorf("-query is required")
+	}
+
+	client := newMappingClient(*apiKey)
+
+	result, err := client.Search(ctx, openfigi.SearchRequest{
+		Query:    *query,
+		ExchCode: *exchCode,
+		Currency: *currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(result)
+}
+
+// newMappingClient creates an openfigi.MappingClient, attaching an API key if one was given.
+func newMappingClient(apiKey string) openfigi.MappingClient {
+	if apiKey == "" {
+		return openfigi.NewMappingClient()
+	}
+
+	return openfigi.NewMappingClient(openfigi.WithAPIKey(apiKey))
+}
+
+// readMappingJobs loads mapping jobs from a JSON or CSV file. The JSON format is a plain
+// array of openfigi.MappingJob objects; the CSV format is a header row of MappingJob field
+// names (idType, idValue, exchCode, ...) followed by one job per row.
+func readMappingJobs(path, format string) ([]openfigi.MappingJob, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch format {
+	case "json":
+		var jobs []openfigi.MappingJob
+		if err := json.NewDecoder(file).Decode(&jobs); err != nil {
+			return nil, fmt.Errorf("decoding %s as JSON: %w", path, err)
+		}
+		return jobs, nil
+	case "csv":
+		return readMappingJobsCSV(file)
+	default:
+		return nil, fmt.Errorf("unknown format %q (expected \"json\" or \"csv\")", format)
+	}
+}
+
+// readMappingJobsCSV parses mapping jobs from a CSV file whose header row names the
+// openfigi.MappingJob fields present in each column. Only idType, idValue, exchCode,
+// micCode, currency, marketSecDes, securityType, securityType2, stateCode, and
+// includeUnlistedEquities are supported as CSV columns; optionType and the array-valued
+// fields (strike, expiration, etc.) require JSON input.
+func readMappingJobsCSV(file *os.File) ([]openfigi.MappingJob, error) {
+	reader := csv.NewReader(file)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	columnIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		columnIndex[name] = i
+	}
+
+	var jobs []openfigi.MappingJob
+
+	for {
+		row, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		job := openfigi.MappingJob{
+			IDType:        csvField(row, columnIndex, "idType"),
+			IDValue:       csvField(row, columnIndex, "idValue"),
+			ExchCode:      csvField(row, columnIndex, "exchCode"),
+			MicCode:       csvField(row, columnIndex, "micCode"),
+			Currency:      csvField(row, columnIndex, "currency"),
+			MarketSecDes:  csvField(row, columnIndex, "marketSecDes"),
+			SecurityType:  csvField(row, columnIndex, "securityType"),
+			SecurityType2: csvField(row, columnIndex, "securityType2"),
+			StateCode:     csvField(row, columnIndex, "stateCode"),
+		}
+
+		if include := csvField(row, columnIndex, "includeUnlistedEquities"); include != "" {
+			job.IncludeUnlistedEquities, _ = strconv.ParseBool(include)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// csvField returns the value of column in row, or "" if column is not present in the header.
+func csvField(row []string, columnIndex map[string]int, column string) string {
+	i, ok := columnIndex[column]
+	if !ok || i >= len(row) {
+		return ""
+	}
+
+	return row[i]
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/Gandook/openfigi-lib/openfigi"
+)
+
+// csvValidationHeader names the columns csvValidationRow writes, in order.
+var csvValidationHeader = []string{
+	"input", "isValid", "message", "reason", "position", "expectedChecksum", "actualChecksum",
+}
+
+// runValidate executes a "validate" command to validate a given string.
+func runValidate(svc openfigi.FIGIService, args []string) error {
+	command := flag.NewFlagSet("validate", flag.ExitOnError)
+	s := command.String("s", "", "String to validate")
+	format := command.String("format", "text", "Output format: \"text\", \"json\", \"ndjson\", or \"csv\"")
+	err := command.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	result := svc.ValidateDetailed(*s)
+
+	switch *format {
+	case "text":
+		if result.IsValid {
+			fmt.Println("Valid")
+		} else {
+			fmt.Printf("Invalid (Reason: %s)\n", result.Message)
+		}
+		return nil
+	case "json", "ndjson":
+		return json.NewEncoder(os.Stdout).Encode(result)
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		if err := w.Write(csvValidationHeader); err != nil {
+			return err
+		}
+		if err := w.Write(csvValidationRow(result)); err != nil {
+			return err
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		return fmt.Errorf("unknown format %q (expected \"text\", \"json\", \"ndjson\", or \"csv\")", *format)
+	}
+}
+
+// runValstream executes a "valstream" command to validate OpenFIGI symbols from a file or stdin.
+func runValstream(ctx context.Context, svc openfigi.FIGIService, args []string) error {
+	command := flag.NewFlagSet("valstream", flag.ExitOnError)
+	format := command.String("format", "text", "Output format: \"text\", \"json\", \"ndjson\", or \"csv\"")
+	err := command.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "text", "json", "ndjson", "csv":
+	default:
+		return fmt.Errorf("unknown format %q (expected \"text\", \"json\", \"ndjson\", or \"csv\")", *format)
+	}
+
+	var reader io.Reader
+
+	if command.NArg() == 0 { // No file provided, use stdin instead
+		reader = os.Stdin
+	} else {
+		file, openingErr := os.Open(command.Arg(0))
+		if openingErr != nil {
+			return openingErr
+		}
+
+		defer func(file *os.File) {
+			err = file.Close()
+			if err != nil {
+				log.Fatalf("Error closing file: %v", err)
+			}
+		}(file)
+
+		reader = file
+	}
+
+	rcvChan := svc.ValidateStream(ctx, reader)
+
+	switch *format {
+	case "json":
+		return writeValstreamJSONArray(ctx, rcvChan)
+	case "ndjson":
+		return writeValstreamNDJSON(ctx, rcvChan)
+	case "csv":
+		return writeValstreamCSV(ctx, rcvChan)
+	default:
+		return writeValstreamText(ctx, rcvChan)
+	}
+}
+
+// writeValstreamText writes results in the original human-readable "<input> is valid" /
+// "<input> is invalid (reason: <message>)" format, one line per result.
+func writeValstreamText(ctx context.Context, rcvChan <-chan openfigi.ValidationResult) error {
+	for {
+		select {
+		case <-ctx.Done(): // Unexpected interruption
+			return ctx.Err()
+		case result, ok := <-rcvChan:
+			if !ok {
+				return nil
+			}
+
+			fmt.Printf("%s is ", result.Input)
+			if result.IsValid {
+				fmt.Println("valid")
+			} else {
+				fmt.Printf("invalid (reason: %s)\n", result.Message)
+			}
+		}
+	}
+}
+
+// writeValstreamNDJSON writes results as newline-delimited JSON, one object per line, so
+// the output streams cleanly through tools like jq as it's produced.
+func writeValstreamNDJSON(ctx context.Context, rcvChan <-chan openfigi.ValidationResult) error {
+	encoder := json.NewEncoder(os.Stdout)
+
+	for {
+		select {
+		case <-ctx.Done(): // Unexpected interruption
+			return ctx.Err()
+		case result, ok := <-rcvChan:
+			if !ok {
+				return nil
+			}
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeValstreamJSONArray writes results as a single JSON array, emitting each element as
+// it arrives rather than buffering the whole stream in memory.
+func writeValstreamJSONArray(ctx context.Context, rcvChan <-chan openfigi.ValidationResult) error {
+	fmt.Print("[")
+	first := true
+
+	for {
+		select {
+		case <-ctx.Done(): // Unexpected interruption
+			return ctx.Err()
+		case result, ok := <-rcvChan:
+			if !ok {
+				fmt.Println("]")
+				return nil
+			}
+
+			data, err := json.Marshal(result)
+			if err != nil {
+				return err
+			}
+
+			if !first {
+				fmt.Print(",")
+			}
+			first = false
+
+			if _, err := os.Stdout.Write(data); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeValstreamCSV writes a header row followed by one row per result.
+func writeValstreamCSV(ctx context.Context, rcvChan <-chan openfigi.ValidationResult) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(csvValidationHeader); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done(): // Unexpected interruption
+			return ctx.Err()
+		case result, ok := <-rcvChan:
+			if !ok {
+				w.Flush()
+				return w.Error()
+			}
+			if err := w.Write(csvValidationRow(result)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// csvValidationRow converts a ValidationResult into a row matching csvValidationHeader.
+// Position is rendered empty when it's -1 (not tied to a single character), and
+// ExpectedChecksum/ActualChecksum are rendered empty when unset (0).
+func csvValidationRow(result openfigi.ValidationResult) []string {
+	position := ""
+	if result.Position >= 0 {
+		position = strconv.Itoa(result.Position)
+	}
+
+	expectedChecksum := ""
+	if result.ExpectedChecksum != 0 {
+		expectedChecksum = string(result.ExpectedChecksum)
+	}
+
+	actualChecksum := ""
+	if result.ActualChecksum != 0 {
+		actualChecksum = string(result.ActualChecksum)
+	}
+
+	return []string{
+		result.Input,
+		strconv.FormatBool(result.IsValid),
+		result.Message,
+		result.Reason.String(),
+		position,
+		expectedChecksum,
+		actualChecksum,
+	}
+}
@@ -0,0 +1,77 @@
+package openfigi
+
+import (
+	"hash/maphash"
+	"math"
+)
+
+// bloomFalsePositiveRate is the target false-positive rate bloomFilters are sized for.
+const bloomFalsePositiveRate = 1e-6
+
+// bloomFilter is a fixed-size Bloom filter: a probabilistic set that never reports a false
+// negative (TestAndAdd never claims an item is absent when it was previously added) but may,
+// at a tunable rate, falsely claim an absent item is present.
+type bloomFilter struct {
+	bits         []uint64
+	m            uint64 // number of bits in use; len(bits)*64 is m rounded up to a multiple of 64.
+	k            uint64 // number of hash functions.
+	seed1, seed2 maphash.Seed
+}
+
+// newBloomFilter creates a bloomFilter sized to hold n items at a target false-positive rate
+// of p, following the standard formulas m = -n*ln(p)/(ln2)^2 for the bit count and
+// k = (m/n)*ln2 for the number of hash functions.
+func newBloomFilter(n uint, p float64) *bloomFilter {
+	nf := math.Max(float64(n), 1)
+
+	m := uint64(math.Ceil(-nf * math.Log(p) / (math.Ln2 * math.Ln2)))
+	if m < 64 {
+		m = 64
+	}
+
+	k := uint64(math.Round((float64(m) / nf) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return &bloomFilter{
+		bits:  make([]uint64, (m+63)/64),
+		m:     m,
+		k:     k,
+		seed1: maphash.MakeSeed(),
+		seed2: maphash.MakeSeed(),
+	}
+}
+
+// hash returns the 64-bit hash of s under seed.
+func (f *bloomFilter) hash(s string, seed maphash.Seed) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	h.WriteString(s)
+	return h.Sum64()
+}
+
+// TestAndAdd reports whether s was already (possibly falsely) present in f, then
+// unconditionally sets its bits so a later call for the same s is guaranteed to report
+// present. It combines two independent 64-bit hashes of s (h1, h2) into f.k hash positions
+// via the standard double-hashing scheme h_i = h1 + i*h2, avoiding the need for f.k
+// independent hash functions.
+func (f *bloomFilter) TestAndAdd(s string) bool {
+	h1 := f.hash(s, f.seed1)
+	h2 := f.hash(s, f.seed2)
+
+	present := true
+
+	for i := uint64(0); i < f.k; i++ {
+		idx := (h1 + i*h2) % f.m
+		word, bit := idx/64, idx%64
+
+		if f.bits[word]&(1<<bit) == 0 {
+			present = false
+		}
+
+		f.bits[word] |= 1 << bit
+	}
+
+	return present
+}
@@ -0,0 +1,47 @@
+package openfigi
+
+import "testing"
+
+func TestBloomFilterNoFalseNegatives(t *testing.T) {
+	filter := newBloomFilter(1_000, bloomFalsePositiveRate)
+
+	symbols := NewService().Generate(1_000)
+
+	for _, symbol := range symbols {
+		if filter.TestAndAdd(symbol) {
+			t.Fatalf("Expected %q to be absent on its first TestAndAdd.", symbol)
+		}
+	}
+
+	for _, symbol := range symbols {
+		if !filter.TestAndAdd(symbol) {
+			t.Errorf("Expected %q to be reported present after being added.", symbol)
+		}
+	}
+}
+
+func TestBloomFilterFalsePositiveRateIsLow(t *testing.T) {
+	const inserted = 10_000
+	const probed = 10_000
+
+	filter := newBloomFilter(inserted, bloomFalsePositiveRate)
+
+	service := NewService()
+	for _, symbol := range service.Generate(inserted) {
+		filter.TestAndAdd(symbol)
+	}
+
+	falsePositives := 0
+	for _, symbol := range service.Generate(probed) {
+		if filter.TestAndAdd(symbol) {
+			falsePositives++
+		}
+	}
+
+	// bloomFalsePositiveRate is a target, not a guarantee; allow considerable slack so the
+	// test isn't flaky, while still catching a filter that's grossly mis-sized.
+	if maxAllowed := probed / 100; falsePositives > maxAllowed {
+		t.Errorf("Expected at most %d false positives out of %d probes, got %d.",
+			maxAllowed, probed, falsePositives)
+	}
+}
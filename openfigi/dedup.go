@@ -0,0 +1,90 @@
+package openfigi
+
+// DedupStrategy selects how Generate and GenerateStream recognize and discard duplicate
+// symbols while generating n of them.
+type DedupStrategy int
+
+const (
+	// DedupExact tracks every generated symbol in a map, guaranteeing no duplicates are ever
+	// emitted. This is the default. Memory grows linearly with n (roughly 80+ bytes per
+	// 12-byte symbol once map overhead is included), which becomes multi-gigabyte heaps, and
+	// the long GC pauses that come with them, for n in the tens of millions.
+	DedupExact DedupStrategy = iota
+	// DedupBloom tracks generated symbols in a Bloom filter sized from the requested n and a
+	// fixed target false-positive rate. Memory still grows linearly with n, but at about 29
+	// bits (under 4 bytes) per symbol instead of the map's 80+ bytes, at the cost of
+	// occasionally (and increasingly rarely as false-positive rate drops) discarding a
+	// would-be-unique symbol as a suspected duplicate and regenerating it instead.
+	DedupBloom
+	// DedupNone performs no de-duplication at all: every generated symbol is emitted
+	// immediately. This makes Generate and GenerateStream truly streaming and
+	// constant-memory, at the cost of a small (and, over a long enough run, eventually
+	// non-negligible) chance of emitting the same symbol twice.
+	DedupNone
+)
+
+// String returns a short, human-readable name for s.
+func (s DedupStrategy) String() string {
+	switch s {
+	case DedupExact:
+		return "exact"
+	case DedupBloom:
+		return "bloom"
+	case DedupNone:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
+// dedupSet tracks which generated symbols have already been produced, with the specific
+// trade-off between memory use and false positives left to the implementation.
+type dedupSet interface {
+	// SeenOrAdd reports whether s has already been produced (or, for a probabilistic
+	// implementation, is suspected to have been). If it reports false, s is recorded as
+	// seen before returning, except by noneDedupSet, which records nothing.
+	SeenOrAdd(s string) bool
+}
+
+// newDedupSet creates the dedupSet matching strategy, sized as appropriate for generating
+// roughly n symbols.
+func newDedupSet(strategy DedupStrategy, n uint) dedupSet {
+	switch strategy {
+	case DedupBloom:
+		return &bloomDedupSet{filter: newBloomFilter(n, bloomFalsePositiveRate)}
+	case DedupNone:
+		return noneDedupSet{}
+	default:
+		return &exactDedupSet{seen: make(map[string]bool, n)}
+	}
+}
+
+// exactDedupSet is the DedupExact dedupSet: a plain map, with zero false positives.
+type exactDedupSet struct {
+	seen map[string]bool
+}
+
+func (e *exactDedupSet) SeenOrAdd(s string) bool {
+	if e.seen[s] {
+		return true
+	}
+
+	e.seen[s] = true
+	return false
+}
+
+// bloomDedupSet is the DedupBloom dedupSet.
+type bloomDedupSet struct {
+	filter *bloomFilter
+}
+
+func (b *bloomDedupSet) SeenOrAdd(s string) bool {
+	return b.filter.TestAndAdd(s)
+}
+
+// noneDedupSet is the DedupNone dedupSet: it never considers anything seen.
+type noneDedupSet struct{}
+
+func (noneDedupSet) SeenOrAdd(string) bool {
+	return false
+}
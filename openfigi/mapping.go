@@ -0,0 +1,393 @@
+package openfigi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMappingBaseURL is the root of the OpenFIGI v3 HTTP API.
+	defaultMappingBaseURL = "https://api.openfigi.com/v3"
+
+	mappingPath = "/mapping"
+	searchPath  = "/search"
+	filterPath  = "/filter"
+
+	apiKeyHeader = "X-OPENFIGI-APIKEY"
+
+	// noKeyRequestLimit/noKeyRequestWindow and keyRequestLimit/keyRequestWindow are the rate
+	// limits OpenFIGI documents for unauthenticated and API-key-authenticated requests,
+	// respectively.
+	noKeyRequestLimit  = 25
+	noKeyRequestWindow = time.Minute
+	noKeyMaxJobs       = 10
+
+	keyRequestLimit  = 25
+	keyRequestWindow = 6 * time.Second
+	keyMaxJobs       = 100
+)
+
+// MappingJob describes a single third-party identifier to resolve to a FIGI via the
+// OpenFIGI /v3/mapping endpoint. IDType and IDValue are required; every other field narrows
+// the search the same way it does on openfigi.com.
+type MappingJob struct {
+	IDType                  string    `json:"idType"`
+	IDValue                 string    `json:"idValue"`
+	ExchCode                string    `json:"exchCode,omitempty"`
+	MicCode                 string    `json:"micCode,omitempty"`
+	Currency                string    `json:"currency,omitempty"`
+	MarketSecDes            string    `json:"marketSecDes,omitempty"`
+	SecurityType            string    `json:"securityType,omitempty"`
+	SecurityType2           string    `json:"securityType2,omitempty"`
+	IncludeUnlistedEquities bool      `json:"includeUnlistedEquities,omitempty"`
+	OptionType              string    `json:"optionType,omitempty"`
+	Strike                  []float64 `json:"strike,omitempty"`
+	ContractSize            []float64 `json:"contractSize,omitempty"`
+	Coupon                  []float64 `json:"coupon,omitempty"`
+	Expiration              []string  `json:"expiration,omitempty"`
+	Maturity                []string  `json:"maturity,omitempty"`
+	StateCode               string    `json:"stateCode,omitempty"`
+}
+
+// MappingResult is a single FIGI record returned for a MappingJob, a search, or a filter
+// query.
+type MappingResult struct {
+	FIGI                string `json:"figi"`
+	SecurityType        string `json:"securityType"`
+	MarketSector        string `json:"marketSector"`
+	Ticker              string `json:"ticker"`
+	Name                string `json:"name"`
+	ExchCode            string `json:"exchCode"`
+	ShareClassFIGI      string `json:"shareClassFIGI"`
+	CompositeFIGI       string `json:"compositeFIGI"`
+	SecurityType2       string `json:"securityType2"`
+	SecurityDescription string `json:"securityDescription"`
+}
+
+// MappingJobResult is the outcome of a single MappingJob. Exactly one of Data or Error is
+// populated, mirroring the shape OpenFIGI returns for each element of a /v3/mapping request.
+type MappingJobResult struct {
+	Data  []MappingResult `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// SearchRequest narrows a free-text /v3/search or /v3/filter query the same way MappingJob
+// narrows a mapping lookup. Search and Filter both send every non-empty field; Start, when
+// set, is the Next value of a previous SearchResult or FilterResult and fetches the
+// following page.
+type SearchRequest struct {
+	Query                   string
+	ExchCode                string
+	MicCode                 string
+	Currency                string
+	MarketSecDes            string
+	SecurityType            string
+	SecurityType2           string
+	IncludeUnlistedEquities bool
+	OptionType              string
+	StateCode               string
+	Start                   string
+}
+
+// SearchResult is the response of a /v3/search query. Next, when non-empty, is passed back
+// via SearchRequest.Start to fetch the following page.
+type SearchResult struct {
+	Data []MappingResult `json:"data"`
+	Next string          `json:"next,omitempty"`
+}
+
+// FilterResult is the response of a /v3/filter query. It additionally reports the total
+// number of records matching the query, independent of how many are returned on this page.
+type FilterResult struct {
+	Data  []MappingResult `json:"data"`
+	Next  string          `json:"next,omitempty"`
+	Total int             `json:"total"`
+}
+
+// MappingClient talks to the OpenFIGI web service to map third-party identifiers to FIGIs
+// and to search the OpenFIGI catalog by free text. Unlike FIGIService, every method on
+// MappingClient performs a network call.
+type MappingClient interface {
+	// Map resolves jobs to FIGIs via the /v3/mapping endpoint. The returned slice has exactly
+	// len(jobs) elements, in the same order as jobs; large slices are transparently split into
+	// as many requests as the client's batch size and rate limit require.
+	Map(ctx context.Context, jobs []MappingJob) ([]MappingJobResult, error)
+
+	// Search performs a free-text lookup via the /v3/search endpoint.
+	Search(ctx context.Context, req SearchRequest) (*SearchResult, error)
+
+	// Filter performs a free-text lookup via the /v3/filter endpoint, additionally reporting
+	// the total number of matching records.
+	Filter(ctx context.Context, req SearchRequest) (*FilterResult, error)
+}
+
+// MappingOption configures a MappingClient constructed with NewMappingClient.
+type MappingOption func(*defaultMappingClient)
+
+// WithAPIKey sets the OpenFIGI API key to send on every request, raising the client's rate
+// limit and batch size to the authenticated tier (25 requests/6s, 100 jobs/request instead of
+// 25 requests/min, 10 jobs/request).
+func WithAPIKey(key string) MappingOption {
+	return func(c *defaultMappingClient) {
+		c.apiKey = key
+	}
+}
+
+// WithHTTPClient overrides the http.Client used to reach the OpenFIGI API. This is primarily
+// useful for tests, which can point it at an httptest.Server.
+func WithHTTPClient(client *http.Client) MappingOption {
+	return func(c *defaultMappingClient) {
+		c.httpClient = client
+	}
+}
+
+// WithBaseURL overrides the OpenFIGI API root (default "https://api.openfigi.com/v3"). This
+// is primarily useful for tests.
+func WithBaseURL(baseURL string) MappingOption {
+	return func(c *defaultMappingClient) {
+		c.baseURL = baseURL
+	}
+}
+
+// defaultMappingClient implements MappingClient.
+type defaultMappingClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+
+	limiter             *tokenBucket
+	maxJobsPerRequest   int
+	fallbackRetryWindow time.Duration
+}
+
+// NewMappingClient creates a new MappingClient. Without WithAPIKey, the client observes
+// OpenFIGI's unauthenticated rate limit and batch size; WithAPIKey raises both.
+func NewMappingClient(opts ...MappingOption) MappingClient {
+	c := &defaultMappingClient{
+		httpClient: http.DefaultClient,
+		baseURL:    defaultMappingBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.apiKey != "" {
+		c.limiter = newTokenBucket(keyRequestLimit, keyRequestWindow)
+		c.maxJobsPerRequest = keyMaxJobs
+		c.fallbackRetryWindow = keyRequestWindow
+	} else {
+		c.limiter = newTokenBucket(noKeyRequestLimit, noKeyRequestWindow)
+		c.maxJobsPerRequest = noKeyMaxJobs
+		c.fallbackRetryWindow = noKeyRequestWindow
+	}
+
+	return c
+}
+
+// Map resolves jobs to FIGIs via the /v3/mapping endpoint.
+func (c *defaultMappingClient) Map(ctx context.Context, jobs []MappingJob) ([]MappingJobResult, error) {
+	results := make([]MappingJobResult, 0, len(jobs))
+
+	for start := 0; start < len(jobs); start += c.maxJobsPerRequest {
+		end := start + c.maxJobsPerRequest
+		if end > len(jobs) {
+			end = len(jobs)
+		}
+
+		batch, err := c.mapBatch(ctx, jobs[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("openfigi: mapping jobs %d-%d: %w", start, end, err)
+		}
+
+		results = append(results, batch...)
+	}
+
+	return results, nil
+}
+
+func (c *defaultMappingClient) mapBatch(ctx context.Context, jobs []MappingJob) ([]MappingJobResult, error) {
+	body, err := json.Marshal(jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []MappingJobResult
+	err = c.doWithRetry(ctx, mappingPath, body, &results)
+	return results, err
+}
+
+// Search performs a free-text lookup via the /v3/search endpoint.
+func (c *defaultMappingClient) Search(ctx context.Context, req SearchRequest) (*SearchResult, error) {
+	var result SearchResult
+	if err := c.doWithRetry(ctx, searchPath, searchRequestBody(req), &result); err != nil {
+		return nil, fmt.Errorf("openfigi: search: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Filter performs a free-text lookup via the /v3/filter endpoint.
+func (c *defaultMappingClient) Filter(ctx context.Context, req SearchRequest) (*FilterResult, error) {
+	var result FilterResult
+	if err := c.doWithRetry(ctx, filterPath, searchRequestBody(req), &result); err != nil {
+		return nil, fmt.Errorf("openfigi: filter: %w", err)
+	}
+
+	return &result, nil
+}
+
+// searchRequestBody marshals a SearchRequest into the field names the /v3/search and
+// /v3/filter endpoints expect.
+func searchRequestBody(req SearchRequest) []byte {
+	body, _ := json.Marshal(struct {
+		Query                   string `json:"query"`
+		ExchCode                string `json:"exchCode,omitempty"`
+		MicCode                 string `json:"micCode,omitempty"`
+		Currency                string `json:"currency,omitempty"`
+		MarketSecDes            string `json:"marketSecDes,omitempty"`
+		SecurityType            string `json:"securityType,omitempty"`
+		SecurityType2           string `json:"securityType2,omitempty"`
+		IncludeUnlistedEquities bool   `json:"includeUnlistedEquities,omitempty"`
+		OptionType              string `json:"optionType,omitempty"`
+		StateCode               string `json:"stateCode,omitempty"`
+		Start                   string `json:"start,omitempty"`
+	}{
+		Query:                   req.Query,
+		ExchCode:                req.ExchCode,
+		MicCode:                 req.MicCode,
+		Currency:                req.Currency,
+		MarketSecDes:            req.MarketSecDes,
+		SecurityType:            req.SecurityType,
+		SecurityType2:           req.SecurityType2,
+		IncludeUnlistedEquities: req.IncludeUnlistedEquities,
+		OptionType:              req.OptionType,
+		StateCode:               req.StateCode,
+		Start:                   req.Start,
+	})
+
+	return body
+}
+
+// doWithRetry sends a single POST request to path, honoring the client's rate limiter and
+// retrying on HTTP 429 after waiting for the duration in the Retry-After header.
+func (c *defaultMappingClient) doWithRetry(ctx context.Context, path string, body []byte, out interface{}) error {
+	for {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		resp, err := c.do(ctx, path, body)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait := c.retryAfterDuration(resp.Header.Get("Retry-After"))
+			resp.Body.Close()
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+
+			continue
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			data, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, data)
+		}
+
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+}
+
+func (c *defaultMappingClient) do(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// retryAfterDuration parses the value of a Retry-After header, which OpenFIGI sends as a
+// number of seconds. It falls back to c's own rate-limit window (not necessarily the keyed
+// one) on an empty or malformed value.
+func (c *defaultMappingClient) retryAfterDuration(header string) time.Duration {
+	if header == "" {
+		return c.fallbackRetryWindow
+	}
+
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return c.fallbackRetryWindow
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// tokenBucket is a simple token-bucket rate limiter used to stay within OpenFIGI's documented
+// request limits.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	max    float64
+	refill float64 // tokens added per second
+	last   time.Time
+}
+
+// newTokenBucket creates a tokenBucket holding requests tokens that refills at a constant
+// rate so that, on average, no more than requests tokens are consumed per window.
+func newTokenBucket(requests int, window time.Duration) *tokenBucket {
+	return &tokenBucket{
+		tokens: float64(requests),
+		max:    float64(requests),
+		refill: float64(requests) / window.Seconds(),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+
+		now := time.Now()
+		elapsed := now.Sub(b.last).Seconds()
+		b.tokens = math.Min(b.max, b.tokens+elapsed*b.refill)
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.refill * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package openfigi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMapSplitsIntoBatchesAtMaxJobsPerRequest(t *testing.T) {
+	var requestSizes []int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var jobs []MappingJob
+		if err := json.NewDecoder(r.Body).Decode(&jobs); err != nil {
+			t.Fatalf("Failed to decode request body: %v.", err)
+		}
+
+		requestSizes = append(requestSizes, len(jobs))
+
+		results := make([]MappingJobResult, len(jobs))
+		for i := range jobs {
+			results[i] = MappingJobResult{Data: []MappingResult{{FIGI: jobs[i].IDValue}}}
+		}
+
+		json.NewEncoder(w).Encode(results)
+	}))
+	defer server.Close()
+
+	client := NewMappingClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	jobs := make([]MappingJob, noKeyMaxJobs+1)
+	for i := range jobs {
+		jobs[i] = MappingJob{IDType: "ID_ISIN", IDValue: strconv.Itoa(i)}
+	}
+
+	results, err := client.Map(context.Background(), jobs)
+	if err != nil {
+		t.Fatalf("Map returned an unexpected error: %v.", err)
+	}
+
+	if len(results) != len(jobs) {
+		t.Fatalf("Expected %d results, got %d.", len(jobs), len(results))
+	}
+
+	if want := []int{noKeyMaxJobs, 1}; !equalInts(requestSizes, want) {
+		t.Errorf("Expected batches of sizes %v, got %v.", want, requestSizes)
+	}
+
+	for i, result := range results {
+		if len(result.Data) != 1 || result.Data[0].FIGI != strconv.Itoa(i) {
+			t.Errorf("Expected result %d to preserve job order, got %+v.", i, result)
+		}
+	}
+}
+
+func TestMapRetriesOnTooManyRequests(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		json.NewEncoder(w).Encode([]MappingJobResult{{Data: []MappingResult{{FIGI: "BBG000BLNNH6"}}}})
+	}))
+	defer server.Close()
+
+	client := NewMappingClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := client.Map(ctx, []MappingJob{{IDType: "ID_ISIN", IDValue: "US0000000000"}})
+	if err != nil {
+		t.Fatalf("Map returned an unexpected error: %v.", err)
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("Expected 2 attempts (one 429 followed by a retry), got %d.", got)
+	}
+
+	if len(results) != 1 || len(results[0].Data) != 1 || results[0].Data[0].FIGI != "BBG000BLNNH6" {
+		t.Errorf("Expected the retried response to be returned, got %+v.", results)
+	}
+}
+
+func TestMapSurfacesNon200Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("internal error"))
+	}))
+	defer server.Close()
+
+	client := NewMappingClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	_, err := client.Map(context.Background(), []MappingJob{{IDType: "ID_ISIN", IDValue: "US0000000000"}})
+	if err == nil {
+		t.Fatal("Expected an error for a non-200 response, got nil.")
+	}
+}
+
+func TestSearchSendsRequestFieldsAndParsesNext(t *testing.T) {
+	var body map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != searchPath {
+			t.Errorf("Expected a request to %q, got %q.", searchPath, r.URL.Path)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v.", err)
+		}
+
+		json.NewEncoder(w).Encode(SearchResult{
+			Data: []MappingResult{{FIGI: "BBG000BLNNH6"}},
+			Next: "eyJvZmZzZXQiOjEwMH0=",
+		})
+	}))
+	defer server.Close()
+
+	client := NewMappingClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	result, err := client.Search(context.Background(), SearchRequest{
+		Query:    "apple",
+		ExchCode: "US",
+		Start:    "eyJvZmZzZXQiOjB9",
+	})
+	if err != nil {
+		t.Fatalf("Search returned an unexpected error: %v.", err)
+	}
+
+	wantBody := map[string]interface{}{
+		"query":    "apple",
+		"exchCode": "US",
+		"start":    "eyJvZmZzZXQiOjB9",
+	}
+	for key, want := range wantBody {
+		if got := body[key]; got != want {
+			t.Errorf("Expected request field %q to be %v, got %v.", key, want, got)
+		}
+	}
+	if _, ok := body["currency"]; ok {
+		t.Errorf("Expected the omitempty currency field to be absent, got %v.", body["currency"])
+	}
+
+	if len(result.Data) != 1 || result.Data[0].FIGI != "BBG000BLNNH6" {
+		t.Errorf("Expected the parsed result data to round-trip, got %+v.", result.Data)
+	}
+	if result.Next != "eyJvZmZzZXQiOjEwMH0=" {
+		t.Errorf("Expected Next to be parsed from the response, got %q.", result.Next)
+	}
+}
+
+func TestFilterSendsRequestFieldsAndParsesTotal(t *testing.T) {
+	var body map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != filterPath {
+			t.Errorf("Expected a request to %q, got %q.", filterPath, r.URL.Path)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v.", err)
+		}
+
+		json.NewEncoder(w).Encode(FilterResult{
+			Data:  []MappingResult{{FIGI: "BBG000BLNNH6"}},
+			Total: 42,
+		})
+	}))
+	defer server.Close()
+
+	client := NewMappingClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	result, err := client.Filter(context.Background(), SearchRequest{
+		Query:         "apple",
+		SecurityType:  "Common Stock",
+		SecurityType2: "Equity",
+	})
+	if err != nil {
+		t.Fatalf("Filter returned an unexpected error: %v.", err)
+	}
+
+	wantBody := map[string]interface{}{
+		"query":         "apple",
+		"securityType":  "Common Stock",
+		"securityType2": "Equity",
+	}
+	for key, want := range wantBody {
+		if got := body[key]; got != want {
+			t.Errorf("Expected request field %q to be %v, got %v.", key, want, got)
+		}
+	}
+
+	if len(result.Data) != 1 || result.Data[0].FIGI != "BBG000BLNNH6" {
+		t.Errorf("Expected the parsed result data to round-trip, got %+v.", result.Data)
+	}
+	if result.Total != 42 {
+		t.Errorf("Expected Total to be parsed from the response, got %d.", result.Total)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
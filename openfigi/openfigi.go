@@ -1,11 +1,10 @@
 package openfigi
 
 import (
-	"bufio"
 	"context"
 	"io"
 	"math/rand"
-	"regexp"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -21,51 +20,175 @@ const (
 	figiChars = "0123456789BCDFGHJKLMNPQRSTVWXYZ"
 )
 
-// A valid OpenFIGI symbol:
-// 1. Starts with either "BBG" or "KKG".
-// 2. Contains 8 alphanumeric characters (vowels are not allowed) after that.
-// 3. Contains a checksum digit at the end.
-var validFIGIPattern = regexp.MustCompile(`^(BB|KK)G[0-9BCDFGHJKLMNPQRSTVWXYZ]{8}[0-9]$`)
-
 // ValidationResult represents the result of a validation operation.
 // Input is the examined string.
 // IsValid is true if and only if Input is a valid OpenFIGI symbol.
-// Message is a human-readable description of the result. If Input is invalid, Message will
-// contain additional information about the reason.
+// Message is a human-readable description of the result, kept stable across Reason values
+// for back-compat with Validate's original (bool, string) return: "valid", "pattern
+// mismatch", or "invalid checksum" ("read error: <err>" for ReasonReadError, which
+// ValidateStream reports when it fails to read its input). If Input is invalid, Message will contain additional
+// information about the reason.
+// Reason is the machine-readable classification of Message; see ValidateDetailed.
+// Position is the 0-based index of the offending character for a character-level Reason
+// (ReasonIllegalChar, ReasonVowelInBody, ReasonBadChecksum), 0 for ReasonBadPrefix (the
+// start of the 3-character prefix, whichever of its characters is wrong), or -1 when the
+// failure isn't tied to a single character (ReasonBadLength) or there is no failure
+// (ReasonOK).
+// ExpectedChecksum and ActualChecksum are only set for ReasonBadChecksum: they are the
+// checksum digit Input needed to be valid and the checksum digit it actually had.
 type ValidationResult struct {
 	Input   string
 	IsValid bool
 	Message string
+
+	Reason           Reason
+	Position         int
+	ExpectedChecksum byte
+	ActualChecksum   byte
 }
 
 type FIGIService interface {
-	// Validate receives a string and determines if it is a valid OpenFIGI symbol.
+	// Validate receives a string and determines if it is a valid OpenFIGI symbol. It is a
+	// back-compat wrapper around ValidateDetailed for callers that only need a bool and a
+	// message; use ValidateDetailed for the structured Reason, Position, and checksum detail.
 	Validate(figi string) (bool, string)
+	// ValidateDetailed receives a string and determines if it is a valid OpenFIGI symbol,
+	// returning the full structured ValidationResult (including Reason and, where
+	// applicable, Position and the expected/actual checksum).
+	ValidateDetailed(figi string) ValidationResult
 	// ValidateStream reads a large number of strings from an external source (e.g., a file),
 	// validates them, and returns the results via a channel.
 	ValidateStream(ctx context.Context, reader io.Reader) <-chan ValidationResult
 
-	// Generate generates n new valid OpenFIGI symbols.
+	// Generate generates n new valid OpenFIGI symbols. For a service constructed with a
+	// fixed seed (NewServiceWithSeed or NewServiceWithRand), the same n always yields the
+	// same sequence of symbols.
 	// Using this method to create a large number of symbols is NOT recommended.
 	Generate(n uint) []string
 	// GenerateStream generates n new valid OpenFIGI symbols and returns them via a channel.
+	// For a service constructed with a fixed seed (NewServiceWithSeed or NewServiceWithRand),
+	// the same n always yields the same sequence of symbols.
 	// This makes it ideal for creating a large number of symbols.
 	GenerateStream(ctx context.Context, n uint) <-chan string
 }
 
 // defaultFIGIService implements the FIGIService interface.
-// rng is a random number generator used for generating new OpenFIGI symbols.
-// rngLock is a mutex used to ensure no two goroutines use rng at the same time.
+// rng is the random number generator symbol generation draws from; rngLock guards it.
+// generateSingle holds rngLock for the whole symbol rather than once per character, so a
+// generated symbol costs one lock/unlock pair instead of nine. rng is never replaced or
+// discarded after construction (in particular, it is NOT pooled per-goroutine), which is
+// what makes (seed, n) -> symbols deterministic under DedupExact and DedupNone (see
+// WithRandSource for DedupBloom): there is exactly one generator, so there is
+// nothing that can silently draw an extra, unaccounted-for random value and desync the
+// sequence.
+// workers is the number of worker goroutines ValidateStream uses to validate lines
+// concurrently.
+// bufferSize is the buffer size used for the channels returned by ValidateStream and
+// GenerateStream.
+// orderedOutput controls whether ValidateStream preserves input order in its output.
+// dedupStrategy controls how Generate and GenerateStream recognize and discard duplicate
+// symbols; see DedupStrategy.
 type defaultFIGIService struct {
 	rng     *rand.Rand
 	rngLock sync.Mutex
+
+	workers       int
+	bufferSize    int
+	orderedOutput bool
+	dedupStrategy DedupStrategy
+}
+
+// ServiceOption configures a FIGIService constructed with NewServiceWithOptions.
+type ServiceOption func(*defaultFIGIService)
+
+// WithWorkers sets the number of worker goroutines ValidateStream uses to validate lines
+// concurrently. The default is runtime.GOMAXPROCS(0). Values less than 1 are ignored.
+func WithWorkers(n int) ServiceOption {
+	return func(d *defaultFIGIService) {
+		if n > 0 {
+			d.workers = n
+		}
+	}
+}
+
+// WithBufferSize sets the buffer size of the channels ValidateStream and GenerateStream use
+// internally and return to callers. The default is chanBufferSize. Values less than 1 are
+// ignored.
+func WithBufferSize(n int) ServiceOption {
+	return func(d *defaultFIGIService) {
+		if n > 0 {
+			d.bufferSize = n
+		}
+	}
+}
+
+// WithOrderedOutput controls whether ValidateStream preserves input order in its output.
+// Ordered output (the default) re-sequences results behind a reorder buffer, which adds
+// latency and memory proportional to how far ahead of each other workers can get; disabling
+// it lets ValidateStream emit results as soon as they're ready, maximizing throughput for
+// callers that don't care about order.
+func WithOrderedOutput(ordered bool) ServiceOption {
+	return func(d *defaultFIGIService) {
+		d.orderedOutput = ordered
+	}
+}
+
+// WithRandSource sets the random number generator Generate and GenerateStream draw from.
+// Since it is the only generator symbol generation ever uses, a given r (and hence a given
+// seed) always produces the same sequence of generated symbols. The default is seeded from
+// time.Now().UnixNano(), which is NOT reproducible; pass a fixed seed to get reproducible
+// output. Under DedupBloom the filter's hash seeds are chosen at random for every call, so
+// a false-positive rejection, and the regeneration it triggers, can differ between two runs
+// with the same r.
+func WithRandSource(r *rand.Rand) ServiceOption {
+	return func(d *defaultFIGIService) {
+		d.rng = r
+	}
+}
+
+// WithDedupStrategy sets how Generate and GenerateStream recognize and discard duplicate
+// symbols. The default is DedupExact.
+func WithDedupStrategy(strategy DedupStrategy) ServiceOption {
+	return func(d *defaultFIGIService) {
+		d.dedupStrategy = strategy
+	}
 }
 
 // NewService creates a new FIGIService instance.
 func NewService() FIGIService {
-	return &defaultFIGIService{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+	return NewServiceWithOptions()
+}
+
+// NewServiceWithSeed creates a new FIGIService instance whose generated symbols are
+// reproducible: calling Generate or GenerateStream with the same n against two services
+// constructed with the same seed yields the same sequence of symbols.
+func NewServiceWithSeed(seed int64) FIGIService {
+	return NewServiceWithOptions(WithRandSource(rand.New(rand.NewSource(seed))))
+}
+
+// NewServiceWithRand creates a new FIGIService instance that derives all of its generated
+// symbols from r, making generation reproducible whenever r itself is (e.g. it was created
+// from a fixed seed).
+func NewServiceWithRand(r *rand.Rand) FIGIService {
+	return NewServiceWithOptions(WithRandSource(r))
+}
+
+// NewServiceWithOptions creates a new FIGIService instance, applying the given options over
+// the same defaults NewService uses.
+func NewServiceWithOptions(opts ...ServiceOption) FIGIService {
+	d := &defaultFIGIService{
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		workers:       runtime.GOMAXPROCS(0),
+		bufferSize:    chanBufferSize,
+		orderedOutput: true,
+		dedupStrategy: DedupExact,
+	}
+
+	for _, opt := range opts {
+		opt(d)
 	}
+
+	return d
 }
 
 // charValue calculates the corresponding value of a character in Luhn's algorithm, NOT
@@ -108,75 +231,101 @@ func getDigitSum(s string) int {
 
 // Validate receives a string and determines if it is a valid OpenFIGI symbol.
 func (d *defaultFIGIService) Validate(figi string) (bool, string) {
-	if !validFIGIPattern.MatchString(figi) {
-		return false, "pattern mismatch"
-	}
-
-	if getDigitSum(figi)%10 != 0 {
-		return false, "invalid checksum"
-	}
-
-	return true, "valid"
+	result := d.ValidateDetailed(figi)
+	return result.IsValid, result.Message
 }
 
-// ValidateStream reads a large number of strings from an external source (e.g., a file),
-// validates them, and returns the results via a channel.
-func (d *defaultFIGIService) ValidateStream(ctx context.Context, reader io.Reader) <-chan ValidationResult {
-	out := make(chan ValidationResult, chanBufferSize)
+// ValidateDetailed receives a string and determines if it is a valid OpenFIGI symbol,
+// returning the full structured ValidationResult. Characters are checked in the same order
+// the symbol is laid out: length, then prefix, then each of the 8 body characters, then the
+// checksum digit, so Position and Reason always point at the first thing wrong with Input.
+func (d *defaultFIGIService) ValidateDetailed(figi string) ValidationResult {
+	if len(figi) != 12 {
+		return ValidationResult{
+			Input: figi, IsValid: false, Message: "pattern mismatch",
+			Reason: ReasonBadLength, Position: -1,
+		}
+	}
 
-	go func() {
-		defer close(out)
+	if (figi[0:2] != "BB" && figi[0:2] != "KK") || figi[2] != 'G' {
+		return ValidationResult{
+			Input: figi, IsValid: false, Message: "pattern mismatch",
+			Reason: ReasonBadPrefix, Position: 0,
+		}
+	}
 
-		scanner := bufio.NewScanner(reader)
+	for i := 3; i < 11; i++ {
+		c := rune(figi[i])
 
-		var input, message string
-		var isValid bool
-		var result ValidationResult
+		switch {
+		case isVowel(c):
+			return ValidationResult{
+				Input: figi, IsValid: false, Message: "pattern mismatch",
+				Reason: ReasonVowelInBody, Position: i,
+			}
+		case !strings.ContainsRune(figiChars, c):
+			return ValidationResult{
+				Input: figi, IsValid: false, Message: "pattern mismatch",
+				Reason: ReasonIllegalChar, Position: i,
+			}
+		}
+	}
 
-		for scanner.Scan() {
-			input = scanner.Text()
-			isValid, message = d.Validate(input)
+	if !unicode.IsDigit(rune(figi[11])) {
+		return ValidationResult{
+			Input: figi, IsValid: false, Message: "pattern mismatch",
+			Reason: ReasonIllegalChar, Position: 11,
+		}
+	}
 
-			result = ValidationResult{
-				Input:   input,
-				IsValid: isValid,
-				Message: message,
-			}
+	if digitSum := getDigitSum(figi); digitSum%10 != 0 {
+		actual := figi[11]
+		bodySum := digitSum - charValue(rune(actual))
+		expected := byte('0' + (10-bodySum%10)%10)
 
-			select {
-			case <-ctx.Done():
-				return
-			case out <- result:
-				// The result is sent.
-			}
+		return ValidationResult{
+			Input: figi, IsValid: false, Message: "invalid checksum",
+			Reason: ReasonBadChecksum, Position: 11,
+			ExpectedChecksum: expected, ActualChecksum: actual,
 		}
-	}()
+	}
 
-	return out
+	return ValidationResult{Input: figi, IsValid: true, Message: "valid", Reason: ReasonOK, Position: -1}
 }
 
-// generateChar randomly generates a valid OpenFIGI character.
-func (d *defaultFIGIService) generateChar() byte {
-	d.rngLock.Lock()
-	defer d.rngLock.Unlock()
+// isVowel reports whether r is an uppercase vowel. Vowels are excluded from figiChars, so a
+// vowel in an OpenFIGI symbol's body is always invalid; it gets its own Reason
+// (ReasonVowelInBody) because it's a common, easily-explained mistake to generate by hand.
+func isVowel(r rune) bool {
+	switch r {
+	case 'A', 'E', 'I', 'O', 'U':
+		return true
+	default:
+		return false
+	}
+}
 
-	return figiChars[d.rng.Intn(31)]
+// generateChar randomly generates a valid OpenFIGI character using r.
+func generateChar(r *rand.Rand) byte {
+	return figiChars[r.Intn(31)]
 }
 
-// generateSingle randomly generates a single valid OpenFIGI symbol.
+// generateSingle randomly generates a single valid OpenFIGI symbol. It holds rngLock for the
+// whole symbol instead of taking a lock on every random draw.
 func (d *defaultFIGIService) generateSingle() string {
+	d.rngLock.Lock()
+	defer d.rngLock.Unlock()
+
 	var sb strings.Builder
 
-	d.rngLock.Lock()
 	if d.rng.Intn(2) == 0 {
 		sb.WriteString("BBG")
 	} else {
 		sb.WriteString("KKG")
 	}
-	d.rngLock.Unlock()
 
 	for i := 0; i < 8; i++ {
-		sb.WriteByte(d.generateChar())
+		sb.WriteByte(generateChar(d.rng))
 	}
 
 	digitSum := getDigitSum(sb.String())
@@ -189,7 +338,7 @@ func (d *defaultFIGIService) generateSingle() string {
 // Generate generates n new valid OpenFIGI symbols.
 // Using this method to create a large number of symbols is NOT recommended.
 func (d *defaultFIGIService) Generate(n uint) []string {
-	isGenerated := make(map[string]bool)
+	seen := newDedupSet(d.dedupStrategy, n)
 	result := make([]string, 0, n)
 
 	var newSymbolCandidate string
@@ -197,11 +346,10 @@ func (d *defaultFIGIService) Generate(n uint) []string {
 	for uint(len(result)) < n {
 		newSymbolCandidate = d.generateSingle()
 
-		if _, exists := isGenerated[newSymbolCandidate]; exists {
+		if seen.SeenOrAdd(newSymbolCandidate) {
 			continue
 		}
 
-		isGenerated[newSymbolCandidate] = true
 		result = append(result, newSymbolCandidate)
 	}
 
@@ -211,22 +359,24 @@ func (d *defaultFIGIService) Generate(n uint) []string {
 // GenerateStream generates n new valid OpenFIGI symbols and returns them via a channel.
 // This makes it ideal for creating a large number of symbols.
 func (d *defaultFIGIService) GenerateStream(ctx context.Context, n uint) <-chan string {
-	out := make(chan string, chanBufferSize)
-	isGenerated := make(map[string]bool)
+	out := make(chan string, d.bufferSize)
+	seen := newDedupSet(d.dedupStrategy, n)
 
 	var newSymbolCandidate string
 
 	go func() {
 		defer close(out)
 
-		for uint(len(isGenerated)) < n {
+		var emitted uint
+
+		for emitted < n {
 			newSymbolCandidate = d.generateSingle()
 
-			if _, exists := isGenerated[newSymbolCandidate]; exists {
+			if seen.SeenOrAdd(newSymbolCandidate) {
 				continue
 			}
 
-			isGenerated[newSymbolCandidate] = true
+			emitted++
 
 			select {
 			case <-ctx.Done():
@@ -2,7 +2,10 @@ package openfigi
 
 import (
 	"context"
+	"math/rand"
 	"os"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -172,12 +175,68 @@ func TestValidateStream(t *testing.T) {
 	})
 }
 
+func TestValidateDetailed(t *testing.T) {
+	service := NewService()
+
+	t.Run("Valid", func(t *testing.T) {
+		result := service.ValidateDetailed("BBG00HLH6Y37")
+		if !result.IsValid || result.Reason != ReasonOK {
+			t.Errorf("Expected 'BBG00HLH6Y37' to be valid with ReasonOK, got IsValid=%v Reason=%v.",
+				result.IsValid, result.Reason)
+		}
+	})
+
+	t.Run("Bad Length", func(t *testing.T) {
+		result := service.ValidateDetailed("BBG0HLH6Y37")
+		if result.Reason != ReasonBadLength {
+			t.Errorf("Expected Reason=ReasonBadLength, got %v.", result.Reason)
+		}
+	})
+
+	t.Run("Bad Prefix", func(t *testing.T) {
+		result := service.ValidateDetailed("BKG00HLH6Y37")
+		if result.Reason != ReasonBadPrefix || result.Position != 0 {
+			t.Errorf("Expected Reason=ReasonBadPrefix at Position=0, got Reason=%v Position=%d.",
+				result.Reason, result.Position)
+		}
+	})
+
+	t.Run("Vowel In Body", func(t *testing.T) {
+		result := service.ValidateDetailed("BBG00HLH6E37")
+		if result.Reason != ReasonVowelInBody || result.Position != 9 {
+			t.Errorf("Expected Reason=ReasonVowelInBody at Position=9, got Reason=%v Position=%d.",
+				result.Reason, result.Position)
+		}
+	})
+
+	t.Run("Illegal Char (Checksum)", func(t *testing.T) {
+		result := service.ValidateDetailed("BBG00HLH6Y3H")
+		if result.Reason != ReasonIllegalChar || result.Position != 11 {
+			t.Errorf("Expected Reason=ReasonIllegalChar at Position=11, got Reason=%v Position=%d.",
+				result.Reason, result.Position)
+		}
+	})
+
+	t.Run("Bad Checksum", func(t *testing.T) {
+		result := service.ValidateDetailed("BBG0088JSC34")
+		if result.Reason != ReasonBadChecksum {
+			t.Errorf("Expected Reason=ReasonBadChecksum, got %v.", result.Reason)
+		}
+		if result.ActualChecksum != '4' {
+			t.Errorf("Expected ActualChecksum='4', got %q.", result.ActualChecksum)
+		}
+		if valid, _ := service.Validate(strings.Replace("BBG0088JSC34", "4", string(result.ExpectedChecksum), 1)); !valid {
+			t.Errorf("Expected swapping in ExpectedChecksum=%q to produce a valid symbol.", result.ExpectedChecksum)
+		}
+	})
+}
+
 func TestGenerate(t *testing.T) {
-	var symbolsNeeded uint32 = 10
+	var symbolsNeeded uint = 10
 	service := NewService()
 	symbols := service.Generate(symbolsNeeded)
 
-	if uint32(len(symbols)) != symbolsNeeded {
+	if uint(len(symbols)) != symbolsNeeded {
 		t.Errorf("Expected %d symbol(s), got %d.", symbolsNeeded, len(symbols))
 	}
 
@@ -192,11 +251,44 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerateDedupStrategies(t *testing.T) {
+	var symbolsNeeded uint = 500
+
+	for _, strategy := range []DedupStrategy{DedupExact, DedupBloom, DedupNone} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			service := NewServiceWithOptions(WithDedupStrategy(strategy))
+			symbols := service.Generate(symbolsNeeded)
+
+			if uint(len(symbols)) != symbolsNeeded {
+				t.Errorf("Expected %d symbol(s), got %d.", symbolsNeeded, len(symbols))
+			}
+
+			if strategy != DedupNone {
+				seen := make(map[string]bool, len(symbols))
+				for _, symbol := range symbols {
+					if seen[symbol] {
+						t.Errorf("Expected no duplicates under %v, but %q appeared more than once.",
+							strategy, symbol)
+					}
+					seen[symbol] = true
+				}
+			}
+
+			for _, symbol := range symbols {
+				if isValid, message := service.Validate(symbol); !isValid {
+					t.Errorf("Expected '%s' to be a valid OpenFIGI symbol, "+
+						"got invalid with the message: %s.", symbol, message)
+				}
+			}
+		})
+	}
+}
+
 func TestGenerateStream(t *testing.T) {
-	var symbolsNeeded uint32 = 100
+	var symbolsNeeded uint = 100
 	ctx := context.Background()
 	service := NewService()
-	var symbolCount uint32 = 0
+	var symbolCount uint = 0
 
 	symbolsChan := service.GenerateStream(ctx, symbolsNeeded)
 	for symbol := range symbolsChan {
@@ -212,3 +304,99 @@ func TestGenerateStream(t *testing.T) {
 		t.Errorf("Expected %d symbol(s), got %d.", symbolsNeeded, symbolCount)
 	}
 }
+
+func TestGenerateWithSeedIsReproducible(t *testing.T) {
+	var symbolsNeeded uint = 50
+	const seed = 42
+
+	first := NewServiceWithSeed(seed).Generate(symbolsNeeded)
+	second := NewServiceWithSeed(seed).Generate(symbolsNeeded)
+
+	if len(first) != len(second) {
+		t.Fatalf("Expected both runs to produce %d symbols, got %d and %d.",
+			symbolsNeeded, len(first), len(second))
+	}
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected symbol %d to be %q in both runs, got %q and %q.",
+				i, first[i], first[i], second[i])
+		}
+	}
+}
+
+func TestGenerateStreamWithSeedIsReproducible(t *testing.T) {
+	var symbolsNeeded uint = 50
+	const seed = 42
+	ctx := context.Background()
+
+	var first, second []string
+	for symbol := range NewServiceWithSeed(seed).GenerateStream(ctx, symbolsNeeded) {
+		first = append(first, symbol)
+	}
+	for symbol := range NewServiceWithSeed(seed).GenerateStream(ctx, symbolsNeeded) {
+		second = append(second, symbol)
+	}
+
+	if len(first) != len(second) {
+		t.Fatalf("Expected both runs to produce %d symbols, got %d and %d.",
+			symbolsNeeded, len(first), len(second))
+	}
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected symbol %d to be %q in both runs, got %q and %q.",
+				i, first[i], first[i], second[i])
+		}
+	}
+}
+
+func TestNewServiceWithRandIsReproducible(t *testing.T) {
+	var symbolsNeeded uint = 50
+
+	first := NewServiceWithRand(rand.New(rand.NewSource(7))).Generate(symbolsNeeded)
+	second := NewServiceWithRand(rand.New(rand.NewSource(7))).Generate(symbolsNeeded)
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected symbol %d to be %q in both runs, got %q and %q.",
+				i, first[i], first[i], second[i])
+		}
+	}
+}
+
+// BenchmarkGenerateDedupStrategies compares peak memory use across DedupStrategy values for
+// a large GenerateStream run. True peak RSS requires OS-specific sampling (e.g. polling
+// /proc/self/status on Linux), which isn't portable; runtime.MemStats' HeapAlloc and Sys are
+// used instead as a portable, if coarser, proxy for the same trend DedupBloom and DedupNone
+// exist to fix: DedupExact's map should dominate both figures at n = 10_000_000, while
+// DedupBloom and DedupNone should look close to flat by comparison.
+func BenchmarkGenerateDedupStrategies(b *testing.B) {
+	const n = 10_000_000
+
+	for _, strategy := range []DedupStrategy{DedupExact, DedupBloom, DedupNone} {
+		b.Run(strategy.String(), func(b *testing.B) {
+			benchmarkGenerateDedup(b, strategy, n)
+		})
+	}
+}
+
+func benchmarkGenerateDedup(b *testing.B, strategy DedupStrategy, n uint) {
+	service := NewServiceWithOptions(WithDedupStrategy(strategy))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		count := 0
+		for range service.GenerateStream(ctx, n) {
+			count++
+		}
+		cancel()
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	b.ReportMetric(float64(mem.HeapAlloc)/(1<<20), "heapAllocMB")
+	b.ReportMetric(float64(mem.Sys)/(1<<20), "sysMB")
+}
@@ -0,0 +1,50 @@
+package openfigi
+
+// Reason is a machine-readable classification of why Validate/ValidateDetailed found a
+// string to be invalid, or ReasonOK if it is valid. Unlike ValidationResult.Message (a
+// fixed, back-compat-friendly string), Reason distinguishes every way a symbol can fail so
+// programmatic consumers don't have to parse free-form text.
+type Reason int
+
+const (
+	// ReasonOK means the input is a valid OpenFIGI symbol.
+	ReasonOK Reason = iota
+	// ReasonBadLength means the input is not exactly 12 characters long.
+	ReasonBadLength
+	// ReasonBadPrefix means the input does not start with "BBG" or "KKG".
+	ReasonBadPrefix
+	// ReasonIllegalChar means a body character is not one of figiChars and is not a vowel
+	// either (a vowel gets the more specific ReasonVowelInBody), or the final checksum
+	// character is not a digit (whether or not it is a vowel or one of figiChars).
+	ReasonIllegalChar
+	// ReasonVowelInBody means a vowel (A, E, I, O, or U) appears in the 8-character body,
+	// where only digits and consonants are allowed.
+	ReasonVowelInBody
+	// ReasonBadChecksum means the input's final digit does not satisfy the Luhn checksum.
+	ReasonBadChecksum
+	// ReasonReadError means the input wasn't validated at all because ValidateStream failed
+	// to read it from its source; see ValidationResult.Message for the underlying error.
+	ReasonReadError
+)
+
+// String returns a short, human-readable name for r.
+func (r Reason) String() string {
+	switch r {
+	case ReasonOK:
+		return "ok"
+	case ReasonBadLength:
+		return "bad length"
+	case ReasonBadPrefix:
+		return "bad prefix"
+	case ReasonIllegalChar:
+		return "illegal character"
+	case ReasonVowelInBody:
+		return "vowel in body"
+	case ReasonBadChecksum:
+		return "bad checksum"
+	case ReasonReadError:
+		return "read error"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,162 @@
+package openfigi
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"io"
+	"sync"
+)
+
+// seqLine pairs a line read from a ValidateStream input with the monotonically-increasing
+// sequence number the dispatcher goroutine assigned it.
+type seqLine struct {
+	seq  uint64
+	line string
+}
+
+// seqResult pairs a ValidationResult with the sequence number of the seqLine it came from.
+type seqResult struct {
+	seq    uint64
+	result ValidationResult
+}
+
+// seqResultHeap is a min-heap of seqResults ordered by seq, used to re-emit results in
+// original input order even though workers may finish them out of order.
+type seqResultHeap []seqResult
+
+func (h seqResultHeap) Len() int            { return len(h) }
+func (h seqResultHeap) Less(i, j int) bool  { return h[i].seq < h[j].seq }
+func (h seqResultHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *seqResultHeap) Push(x interface{}) { *h = append(*h, x.(seqResult)) }
+func (h *seqResultHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// ValidateStream reads a large number of strings from an external source (e.g., a file),
+// validates them, and returns the results via a channel. A dispatcher goroutine scans the
+// input and assigns each line a sequence number, a pool of d.workers goroutines validates
+// lines concurrently, and (unless WithOrderedOutput(false) was used) a reorder stage
+// re-emits results on the returned channel in the same order the lines were read. If the
+// scanner encounters a read error, it is surfaced as a ValidationResult whose Message is
+// "read error: <err>"; with ordered output it is the final result, while with
+// WithOrderedOutput(false) it may arrive before the results of earlier lines. The pool shuts down without leaking goroutines if ctx is done
+// before the input is exhausted.
+func (d *defaultFIGIService) ValidateStream(ctx context.Context, reader io.Reader) <-chan ValidationResult {
+	jobs := make(chan seqLine, d.bufferSize)
+	results := make(chan seqResult, d.bufferSize)
+	out := make(chan ValidationResult, d.bufferSize)
+
+	var writers sync.WaitGroup
+	writers.Add(d.workers + 1) // d.workers validating goroutines, plus the dispatcher itself.
+
+	for i := 0; i < d.workers; i++ {
+		go func() {
+			defer writers.Done()
+
+			for job := range jobs {
+				result := seqResult{
+					seq:    job.seq,
+					result: d.ValidateDetailed(job.line),
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case results <- result:
+					// The result is handed off to the reorder stage.
+				}
+			}
+		}()
+	}
+
+	go dispatch(ctx, reader, jobs, results, &writers)
+
+	go func() {
+		writers.Wait()
+		close(results)
+	}()
+
+	if d.orderedOutput {
+		go reorder(ctx, results, out)
+	} else {
+		go passthrough(ctx, results, out)
+	}
+
+	return out
+}
+
+// dispatch scans reader line-by-line, assigning each line a monotonically-increasing
+// sequence number and sending it to jobs for the worker pool to validate. It closes jobs
+// when done so the worker pool can shut down, and reports writers.Done() once it will not
+// send to jobs or results again. A scanner error is sent directly to results, since it
+// doesn't need validating.
+func dispatch(ctx context.Context, reader io.Reader, jobs chan<- seqLine, results chan<- seqResult, writers *sync.WaitGroup) {
+	defer writers.Done()
+	defer close(jobs)
+
+	scanner := bufio.NewScanner(reader)
+
+	var seq uint64
+
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return
+		case jobs <- seqLine{seq: seq, line: scanner.Text()}:
+			seq++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		result := seqResult{seq: seq, result: ValidationResult{
+			Message: "read error: " + err.Error(), Reason: ReasonReadError, Position: -1,
+		}}
+
+		select {
+		case <-ctx.Done():
+		case results <- result:
+		}
+	}
+}
+
+// reorder re-emits results on out in ascending order of seq, buffering results that arrive
+// ahead of the next expected sequence number in a min-heap.
+func reorder(ctx context.Context, results <-chan seqResult, out chan<- ValidationResult) {
+	defer close(out)
+
+	pending := &seqResultHeap{}
+	var next uint64
+
+	for res := range results {
+		heap.Push(pending, res)
+
+		for pending.Len() > 0 && (*pending)[0].seq == next {
+			item := heap.Pop(pending).(seqResult)
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- item.result:
+				next++
+			}
+		}
+	}
+}
+
+// passthrough re-emits results on out as soon as they arrive, without regard to order.
+func passthrough(ctx context.Context, results <-chan seqResult, out chan<- ValidationResult) {
+	defer close(out)
+
+	for res := range results {
+		select {
+		case <-ctx.Done():
+			return
+		case out <- res.result:
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package openfigi
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidateStreamOrdering(t *testing.T) {
+	service := NewServiceWithOptions(WithWorkers(8), WithOrderedOutput(true))
+	symbols := service.Generate(500)
+
+	input := strings.Join(symbols, "\n")
+
+	results := service.ValidateStream(context.Background(), strings.NewReader(input))
+
+	i := 0
+	for result := range results {
+		if result.Input != symbols[i] {
+			t.Fatalf("Expected result %d to be for %q, got %q.", i, symbols[i], result.Input)
+		}
+		i++
+	}
+
+	if i != len(symbols) {
+		t.Errorf("Expected %d results, got %d.", len(symbols), i)
+	}
+}
+
+func TestValidateStreamUnorderedIsComplete(t *testing.T) {
+	service := NewServiceWithOptions(WithWorkers(8), WithOrderedOutput(false))
+	symbols := service.Generate(500)
+
+	input := strings.Join(symbols, "\n")
+
+	seen := make(map[string]bool, len(symbols))
+	results := service.ValidateStream(context.Background(), strings.NewReader(input))
+
+	for result := range results {
+		seen[result.Input] = true
+	}
+
+	for _, symbol := range symbols {
+		if !seen[symbol] {
+			t.Errorf("Expected %q to appear in the unordered results.", symbol)
+		}
+	}
+}
+
+func TestValidateStreamReadError(t *testing.T) {
+	service := NewService()
+
+	results := service.ValidateStream(context.Background(), &erroringReader{})
+
+	var lastResult ValidationResult
+	for result := range results {
+		lastResult = result
+	}
+
+	if !strings.HasPrefix(lastResult.Message, "read error: ") {
+		t.Errorf("Expected the last result's message to start with \"read error: \", got %q.", lastResult.Message)
+	}
+}
+
+func TestValidateStreamContextCancellation(t *testing.T) {
+	service := NewServiceWithOptions(WithWorkers(2), WithBufferSize(1))
+	symbols := service.Generate(10_000)
+	input := strings.Join(symbols, "\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := service.ValidateStream(ctx, strings.NewReader(input))
+
+	<-results
+	cancel()
+
+	// Draining the channel must complete (i.e. it must be closed) shortly after
+	// cancellation, proving the worker pool shut down instead of leaking goroutines.
+	for range results {
+	}
+}
+
+// erroringReader is an io.Reader that always returns an error after a single short read.
+type erroringReader struct {
+	readOnce bool
+}
+
+func (r *erroringReader) Read(p []byte) (int, error) {
+	if r.readOnce {
+		return 0, bytes.ErrTooLarge
+	}
+
+	r.readOnce = true
+	n := copy(p, []byte("BBG00HLH6Y37\n"))
+	return n, nil
+}
+
+// BenchmarkValidateStream compares single-goroutine throughput against the default worker
+// pool size on a 1M-line input.
+func BenchmarkValidateStream(b *testing.B) {
+	const numLines = 1_000_000
+
+	service := NewService()
+	symbols := service.Generate(1_000)
+
+	var sb strings.Builder
+	for i := 0; i < numLines; i++ {
+		sb.WriteString(symbols[i%len(symbols)])
+		sb.WriteByte('\n')
+	}
+	data := []byte(sb.String())
+
+	b.Run("Workers=1", func(b *testing.B) {
+		benchmarkValidateStream(b, NewServiceWithOptions(WithWorkers(1)), data)
+	})
+
+	b.Run("Workers=GOMAXPROCS", func(b *testing.B) {
+		benchmarkValidateStream(b, NewServiceWithOptions(), data)
+	})
+}
+
+func benchmarkValidateStream(b *testing.B, service FIGIService, data []byte) {
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		reader := bytes.NewReader(data)
+
+		for range service.ValidateStream(context.Background(), reader) {
+			// Draining the channel is the benchmark.
+		}
+	}
+}